@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configoauth2 implements the OAuth2 client authentication flows
+// supported for authenticating outgoing requests against an exporter's
+// backend.
+package configoauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// Flow selects which OAuth2 client flow an OAuth2Authenticator uses to obtain
+// a token.
+type Flow string
+
+const (
+	// FlowClientCredentials is the 2-legged client_credentials flow
+	// authenticated with a shared client secret.
+	FlowClientCredentials Flow = "client_credentials"
+	// FlowClientCredentialsJWT is the client_credentials flow authenticated
+	// with a signed JWT assertion instead of a shared secret (RFC 7523
+	// private_key_jwt client authentication).
+	FlowClientCredentialsJWT Flow = "client_credentials_jwt"
+	// FlowRefreshToken exchanges a long-lived refresh token, loaded from
+	// disk, for access tokens, persisting any rotated refresh token the IdP
+	// returns.
+	FlowRefreshToken Flow = "refresh_token"
+	// FlowTLSClientAuth is the client_credentials flow with no client
+	// secret, where mutual TLS to the token endpoint identifies the client
+	// (RFC 8705).
+	FlowTLSClientAuth Flow = "tls_client_auth"
+)
+
+var (
+	errNoFlowProvided       = errors.New("no flow provided in OAuth2 configuration")
+	errUnknownFlow          = errors.New("unknown flow in OAuth2 configuration")
+	errFlowConfigMissing    = errors.New("OAuth2 configuration selects a flow whose configuration section is not set")
+	errFlowConfigMismatched = errors.New("OAuth2 configuration has settings for a flow other than the one selected")
+)
+
+// tokenSourceConfig is implemented by each flow's configuration section. It
+// returns an oauth2.TokenSource that fetches and refreshes tokens for that
+// flow.
+type tokenSourceConfig interface {
+	TokenSource(ctx context.Context) (oauth2.TokenSource, error)
+}
+
+// OAuth2Authenticator configures how to authenticate outgoing HTTP requests
+// using OAuth2. Flow selects exactly one of the flow-specific sections below;
+// the others must be left unset.
+type OAuth2Authenticator struct {
+	// Flow selects which OAuth2 client flow to use. Defaults to
+	// FlowClientCredentials if OAuth2ClientCredentials is set and Flow is
+	// empty, for backwards compatibility with configurations predating the
+	// "flow" field.
+	Flow Flow `mapstructure:"flow"`
+
+	// OAuth2ClientCredentials configures the client_credentials flow. It is
+	// embedded (rather than nested under a "client_credentials" key) so that
+	// configurations written before OAuth2Authenticator existed - with
+	// client_id/client_secret/token_url/... at the top level - keep working
+	// unchanged.
+	*OAuth2ClientCredentials `mapstructure:",squash"`
+	// ClientCredentialsJWT configures the client_credentials_jwt flow.
+	ClientCredentialsJWT *ClientCredentialsJWTConfig `mapstructure:"client_credentials_jwt,omitempty"`
+	// RefreshToken configures the refresh_token flow.
+	RefreshToken *RefreshTokenConfig `mapstructure:"refresh_token,omitempty"`
+	// TLSClientAuth configures the tls_client_auth flow.
+	TLSClientAuth *TLSClientAuthConfig `mapstructure:"tls_client_auth,omitempty"`
+}
+
+// RoundTripper wraps base with an http.RoundTripper that attaches an OAuth2
+// access token obtained via the configured Flow, refreshing it as needed.
+func (a *OAuth2Authenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	flow, err := a.selectFlow()
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := flow.TokenSource(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Transport{
+		Source: source,
+		Base:   base,
+	}, nil
+}
+
+// selectFlow resolves the configuration's Flow field to the corresponding
+// flow-specific tokenSourceConfig, verifying that exactly one flow's
+// configuration section was set.
+func (a *OAuth2Authenticator) selectFlow() (tokenSourceConfig, error) {
+	flow := a.Flow
+	if flow == "" && a.OAuth2ClientCredentials != nil {
+		flow = FlowClientCredentials
+	}
+	if flow == "" {
+		return nil, errNoFlowProvided
+	}
+
+	configs := map[Flow]tokenSourceConfig{
+		FlowClientCredentials:    a.OAuth2ClientCredentials,
+		FlowClientCredentialsJWT: a.ClientCredentialsJWT,
+		FlowRefreshToken:         a.RefreshToken,
+		FlowTLSClientAuth:        a.TLSClientAuth,
+	}
+
+	selected, ok := configs[flow]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownFlow, flow)
+	}
+	if selected == nil || isNilTokenSourceConfig(selected) {
+		return nil, fmt.Errorf("%w: %q", errFlowConfigMissing, flow)
+	}
+	for other, cfg := range configs {
+		if other == flow {
+			continue
+		}
+		if cfg != nil && !isNilTokenSourceConfig(cfg) {
+			return nil, fmt.Errorf("%w: flow is %q but %q is also configured", errFlowConfigMismatched, flow, other)
+		}
+	}
+	return selected, nil
+}
+
+// isNilTokenSourceConfig reports whether a tokenSourceConfig is a nil pointer
+// stored in the interface, which a plain cfg == nil comparison does not catch
+// once the concrete pointer type has been boxed into the map above.
+func isNilTokenSourceConfig(cfg tokenSourceConfig) bool {
+	switch c := cfg.(type) {
+	case *OAuth2ClientCredentials:
+		return c == nil
+	case *ClientCredentialsJWTConfig:
+		return c == nil
+	case *RefreshTokenConfig:
+		return c == nil
+	case *TLSClientAuthConfig:
+		return c == nil
+	default:
+		return cfg == nil
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// cachingTokenSource wraps an oauth2.TokenSource with an on-disk cache, so a
+// collector restart doesn't need to immediately re-fetch a token from the
+// IdP. Cache reads and writes are serialized since the underlying file is
+// shared with no external locking.
+type cachingTokenSource struct {
+	base oauth2.TokenSource
+	path string
+
+	mu sync.Mutex
+}
+
+func newCachingTokenSource(base oauth2.TokenSource, cacheDir, clientID, tokenURL string, scopes []string) *cachingTokenSource {
+	return &cachingTokenSource{
+		base: base,
+		path: filepath.Join(cacheDir, tokenCacheFileName(clientID, tokenURL, scopes)),
+	}
+}
+
+// tokenCacheFileName derives a stable, non-reversible file name for a given
+// set of OAuth2 client credentials, so distinct exporters/receivers sharing a
+// cache directory don't collide.
+func tokenCacheFileName(clientID, tokenURL string, scopes []string) string {
+	h := sha256.New()
+	h.Write([]byte(clientID))
+	h.Write([]byte{'|'})
+	h.Write([]byte(tokenURL))
+	h.Write([]byte{'|'})
+	h.Write([]byte(strings.Join(scopes, ",")))
+	return hex.EncodeToString(h.Sum(nil)) + ".json"
+}
+
+// Token returns a cached token if one is present on disk and still valid,
+// otherwise it fetches a new one from the wrapped TokenSource and persists it.
+func (s *cachingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tok, ok := s.readCache(); ok && tok.Valid() {
+		return tok, nil
+	}
+
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	// Caching is a best-effort optimization; a failure to persist must not
+	// fail the request that already has a valid token.
+	_ = s.writeCache(tok)
+	return tok, nil
+}
+
+func (s *cachingTokenSource) readCache() (*oauth2.Token, bool) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, false
+	}
+	tok := &oauth2.Token{}
+	if err := json.Unmarshal(data, tok); err != nil {
+		return nil, false
+	}
+	return tok, true
+}
+
+func (s *cachingTokenSource) writeCache(tok *oauth2.Token) error {
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing oauth2 token cache: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var (
+	errNoClientIDProvided     = errors.New("no ClientID provided in OAuth2 client_credentials configuration")
+	errNoTokenURLProvided     = errors.New("no TokenURL provided in OAuth2 client_credentials configuration")
+	errNoClientSecretProvided = errors.New("no ClientSecret provided in OAuth2 client_credentials configuration")
+)
+
+// OAuth2ClientCredentials stores the configuration for OAuth2 Client Credentials
+// (2-legged OAuth2 flow) setup. It is also the client_credentials flow's
+// configuration section of OAuth2Authenticator.
+type OAuth2ClientCredentials struct {
+	// ClientID is the application's ID.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the application's secret.
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// TokenURL is the resource server's token endpoint
+	// URL. This is a constant specific to each server.
+	TokenURL string `mapstructure:"token_url"`
+
+	// Scopes specifies optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+
+	// EndpointParams carries extra token request parameters, e.g. "audience"
+	// or "resource" for Azure AD / AWS Cognito style IdPs.
+	EndpointParams EndpointParams `mapstructure:"endpoint_params,omitempty"`
+
+	// TLSSetting is the TLS configuration used by the HTTP client that fetches
+	// the token from TokenURL, allowing custom CAs or mTLS against private IdPs.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// RetryMaxElapsed is the maximum total time spent retrying a token fetch
+	// before giving up. A value <= 0 falls back to a sane default rather
+	// than retrying forever.
+	RetryMaxElapsed time.Duration `mapstructure:"retry_max_elapsed_time"`
+
+	// TokenCacheDir, if non-empty, enables on-disk caching of the fetched
+	// token in this directory so that a collector restart does not have to
+	// immediately re-authenticate against the IdP. The cache file name is
+	// derived from a hash of the client ID, token URL and scopes.
+	TokenCacheDir string `mapstructure:"token_cache_dir"`
+}
+
+// RoundTripper wraps base with an http.RoundTripper that attaches an OAuth2
+// access token obtained via the client_credentials grant.
+//
+// This method predates OAuth2Authenticator and is kept so that exporters
+// which embed OAuth2ClientCredentials directly, rather than through
+// OAuth2Authenticator, keep compiling and behaving the same.
+func (c *OAuth2ClientCredentials) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	source, err := c.TokenSource(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Transport{
+		Source: source,
+		Base:   base,
+	}, nil
+}
+
+// TokenSource returns an oauth2.TokenSource that fetches tokens using the
+// client_credentials grant, retrying transient failures and, if
+// TokenCacheDir is set, persisting the token across restarts.
+func (c *OAuth2ClientCredentials) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.ClientID == "" {
+		return nil, errNoClientIDProvided
+	}
+	if c.ClientSecret == "" {
+		return nil, errNoClientSecretProvided
+	}
+	if c.TokenURL == "" {
+		return nil, errNoTokenURLProvided
+	}
+
+	tlsCfg, err := c.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, newTokenHTTPClient(tlsCfg))
+
+	cfg := clientcredentials.Config{
+		ClientID:       c.ClientID,
+		ClientSecret:   c.ClientSecret,
+		TokenURL:       c.TokenURL,
+		Scopes:         c.Scopes,
+		EndpointParams: c.EndpointParams.toURLValues(),
+	}
+
+	var source oauth2.TokenSource = newRetryingTokenSource(cfg.TokenSource(ctx), c.RetryMaxElapsed)
+	if c.TokenCacheDir != "" {
+		source = newCachingTokenSource(source, c.TokenCacheDir, c.ClientID, c.TokenURL, c.Scopes)
+	}
+	return source, nil
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jws"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	// clientAssertionTypeJWTBearer is the client_assertion_type value defined
+	// by RFC 7523 Section 2.2 for JWT-based client authentication.
+	clientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	defaultJWTAssertionExpiry = 5 * time.Minute
+)
+
+var (
+	errNoClientIDProvidedJWT    = errors.New("no ClientID provided in OAuth2 client_credentials_jwt configuration")
+	errNoTokenURLProvidedJWT    = errors.New("no TokenURL provided in OAuth2 client_credentials_jwt configuration")
+	errNoPrivateKeyFileProvided = errors.New("no PrivateKeyFile provided in OAuth2 client_credentials_jwt configuration")
+)
+
+// ClientCredentialsJWTConfig stores the configuration for the
+// client_credentials flow authenticated with a signed JWT assertion (RFC
+// 7523 "private_key_jwt") instead of a shared client secret.
+type ClientCredentialsJWTConfig struct {
+	// ClientID is the application's ID. It is used as both the "iss" and
+	// "sub" claim of the signed assertion.
+	ClientID string `mapstructure:"client_id"`
+
+	// TokenURL is the resource server's token endpoint URL. It is used as
+	// the "aud" claim of the signed assertion.
+	TokenURL string `mapstructure:"token_url"`
+
+	// PrivateKeyFile is a PEM-encoded RSA private key used to sign the JWT
+	// assertion.
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+
+	// PrivateKeyID, if set, is sent as the "kid" header of the signed
+	// assertion, for IdPs that need it to select the verification key.
+	PrivateKeyID string `mapstructure:"private_key_id"`
+
+	// AssertionExpiry is how long the signed assertion is valid for before
+	// it must be re-signed. Defaults to 5 minutes.
+	AssertionExpiry time.Duration `mapstructure:"assertion_expiry"`
+
+	// Scopes specifies optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+
+	// EndpointParams carries extra token request parameters, e.g. "audience"
+	// or "resource" for Azure AD / AWS Cognito style IdPs.
+	EndpointParams EndpointParams `mapstructure:"endpoint_params,omitempty"`
+
+	// TLSSetting is the TLS configuration used by the HTTP client that talks
+	// to TokenURL.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// RetryMaxElapsed is the maximum total time spent retrying a token fetch
+	// before giving up. A value <= 0 falls back to a sane default rather
+	// than retrying forever.
+	RetryMaxElapsed time.Duration `mapstructure:"retry_max_elapsed_time"`
+
+	// TokenCacheDir, if non-empty, enables on-disk caching of the fetched
+	// token, see OAuth2ClientCredentials.TokenCacheDir.
+	TokenCacheDir string `mapstructure:"token_cache_dir"`
+}
+
+// TokenSource returns an oauth2.TokenSource that authenticates with the
+// client_credentials grant, signing a fresh JWT assertion with PrivateKeyFile
+// for each token request instead of sending a client secret.
+func (c *ClientCredentialsJWTConfig) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.ClientID == "" {
+		return nil, errNoClientIDProvidedJWT
+	}
+	if c.TokenURL == "" {
+		return nil, errNoTokenURLProvidedJWT
+	}
+	if c.PrivateKeyFile == "" {
+		return nil, errNoPrivateKeyFileProvided
+	}
+	key, err := loadRSAPrivateKey(c.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg, err := c.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := newTokenHTTPClient(tlsCfg)
+
+	expiry := c.AssertionExpiry
+	if expiry <= 0 {
+		expiry = defaultJWTAssertionExpiry
+	}
+
+	base := &jwtBearerAssertionTokenSource{
+		httpClient: httpClient,
+		tokenURL:   c.TokenURL,
+		clientID:   c.ClientID,
+		keyID:      c.PrivateKeyID,
+		key:        key,
+		expiry:     expiry,
+		scopes:     c.Scopes,
+		params:     c.EndpointParams.toURLValues(),
+	}
+
+	var source oauth2.TokenSource = newRetryingTokenSource(oauth2.ReuseTokenSource(nil, base), c.RetryMaxElapsed)
+	if c.TokenCacheDir != "" {
+		source = newCachingTokenSource(source, c.TokenCacheDir, c.ClientID, c.TokenURL, c.Scopes)
+	}
+	return source, nil
+}
+
+// jwtBearerAssertionTokenSource fetches a token from a client_credentials
+// token endpoint, authenticating the client via a freshly-signed JWT
+// assertion (RFC 7523 Section 2.2) rather than a client secret.
+type jwtBearerAssertionTokenSource struct {
+	httpClient *http.Client
+	tokenURL   string
+	clientID   string
+	keyID      string
+	key        *rsa.PrivateKey
+	expiry     time.Duration
+	scopes     []string
+	params     url.Values
+}
+
+func (s *jwtBearerAssertionTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+	claimSet := &jws.ClaimSet{
+		Iss: s.clientID,
+		Sub: s.clientID,
+		Aud: s.tokenURL,
+		Iat: now.Unix(),
+		Exp: now.Add(s.expiry).Unix(),
+	}
+	header := &jws.Header{
+		Algorithm: "RS256",
+		Typ:       "JWT",
+		KeyID:     s.keyID,
+	}
+	assertion, err := jws.Encode(header, claimSet, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	for k, vs := range s.params {
+		values[k] = vs
+	}
+	values.Set("grant_type", "client_credentials")
+	values.Set("client_assertion_type", clientAssertionTypeJWTBearer)
+	values.Set("client_assertion", assertion)
+	if len(s.scopes) > 0 {
+		values.Set("scope", strings.Join(s.scopes, " "))
+	}
+
+	return retrieveToken(s.httpClient, s.tokenURL, values)
+}
+
+// loadRSAPrivateKey reads a PEM-encoded PKCS#1 or PKCS#8 RSA private key from
+// path, as used to sign the client assertion.
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	keyAny, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key in %s: %w", path, err)
+	}
+	key, ok := keyAny.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key in %s is not an RSA key", path)
+	}
+	return key, nil
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import "net/url"
+
+// EndpointParams carries extra parameters to send in the token request body,
+// e.g. "audience" or "resource" for Azure AD / AWS Cognito style IdPs that
+// require them alongside the standard OAuth2 parameters.
+type EndpointParams map[string][]string
+
+func (p EndpointParams) toURLValues() url.Values {
+	if len(p) == 0 {
+		return nil
+	}
+	v := url.Values{}
+	for key, values := range p {
+		v[key] = values
+	}
+	return v
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var (
+	errNoClientIDProvidedRT  = errors.New("no ClientID provided in OAuth2 refresh_token configuration")
+	errNoTokenURLProvidedRT  = errors.New("no TokenURL provided in OAuth2 refresh_token configuration")
+	errNoRefreshTokenFile    = errors.New("no RefreshTokenFile provided in OAuth2 refresh_token configuration")
+	errEmptyRefreshTokenFile = errors.New("RefreshTokenFile contains no refresh token")
+)
+
+// RefreshTokenConfig stores the configuration for exchanging a long-lived
+// refresh token, stored on disk, for short-lived access tokens.
+type RefreshTokenConfig struct {
+	// ClientID is the application's ID.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the application's secret. Optional: some IdPs issue
+	// refresh tokens to public clients that authenticate with ClientID alone.
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// TokenURL is the resource server's token endpoint URL.
+	TokenURL string `mapstructure:"token_url"`
+
+	// RefreshTokenFile holds the initial refresh token. When the IdP rotates
+	// the refresh token, the new value is written back to this file so the
+	// next collector restart picks it up.
+	RefreshTokenFile string `mapstructure:"refresh_token_file"`
+
+	// Scopes specifies optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+
+	// TLSSetting is the TLS configuration used by the HTTP client that talks
+	// to TokenURL.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// RetryMaxElapsed is the maximum total time spent retrying a token fetch
+	// before giving up. A value <= 0 falls back to a sane default rather
+	// than retrying forever.
+	RetryMaxElapsed time.Duration `mapstructure:"retry_max_elapsed_time"`
+}
+
+// TokenSource returns an oauth2.TokenSource that exchanges the refresh token
+// in RefreshTokenFile for access tokens, persisting any rotated refresh token
+// the IdP returns back to that file.
+func (c *RefreshTokenConfig) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.ClientID == "" {
+		return nil, errNoClientIDProvidedRT
+	}
+	if c.TokenURL == "" {
+		return nil, errNoTokenURLProvidedRT
+	}
+	if c.RefreshTokenFile == "" {
+		return nil, errNoRefreshTokenFile
+	}
+
+	initial, err := readRefreshToken(c.RefreshTokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg, err := c.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, newTokenHTTPClient(tlsCfg))
+
+	cfg := &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint:     oauth2.Endpoint{TokenURL: c.TokenURL},
+		Scopes:       c.Scopes,
+	}
+
+	base := cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: initial})
+	rotating := &rotatingRefreshTokenSource{
+		base:                base,
+		path:                c.RefreshTokenFile,
+		lastKnownRefreshTok: initial,
+	}
+	return newRetryingTokenSource(rotating, c.RetryMaxElapsed), nil
+}
+
+func readRefreshToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	tok := strings.TrimSpace(string(data))
+	if tok == "" {
+		return "", errEmptyRefreshTokenFile
+	}
+	return tok, nil
+}
+
+// rotatingRefreshTokenSource wraps the stock oauth2 refreshing TokenSource
+// and persists the refresh token back to disk whenever the IdP rotates it,
+// so the new refresh token survives a collector restart.
+type rotatingRefreshTokenSource struct {
+	base oauth2.TokenSource
+	path string
+
+	mu                  sync.Mutex
+	lastKnownRefreshTok string
+}
+
+func (s *rotatingRefreshTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tok.RefreshToken != "" && tok.RefreshToken != s.lastKnownRefreshTok {
+		if err := os.WriteFile(s.path, []byte(tok.RefreshToken), 0o600); err == nil {
+			s.lastKnownRefreshTok = tok.RefreshToken
+		}
+		// A failure to persist the rotated refresh token is not fatal to this
+		// request, which already has a valid access token; the next refresh
+		// will simply try to persist it again.
+	}
+	return tok, nil
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTokenHTTPClient builds the *http.Client used to fetch a token from a
+// token endpoint. It clones http.DefaultTransport rather than starting from
+// a bare &http.Transport{}, so the token fetch keeps using
+// Proxy: http.ProxyFromEnvironment (HTTPS_PROXY support), connect/TLS
+// handshake timeouts (so a dead IdP can't hang the fetch forever) and
+// idle-connection reuse, only overriding TLSClientConfig.
+func newTokenHTTPClient(tlsCfg *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	return &http.Client{Transport: transport}
+}
+
+const (
+	defaultRetryInitialInterval = 500 * time.Millisecond
+	defaultRetryMultiplier      = 1.5
+	defaultRetryMaxInterval     = 30 * time.Second
+
+	// defaultRetryMaxElapsed bounds how long Token() will keep retrying
+	// against a persistently failing token endpoint when RetryMaxElapsed is
+	// left at its zero value. Without a non-zero default here, a dead IdP
+	// would make Token() retry forever and hang the caller's request.
+	defaultRetryMaxElapsed = 2 * time.Minute
+)
+
+// retryingTokenSource wraps an oauth2.TokenSource with exponential backoff,
+// retrying token fetches that fail due to a network error or a 429/5xx
+// response from the token endpoint. 4xx errors (bad client credentials,
+// invalid scope, ...) are never retried since retrying them can't succeed.
+type retryingTokenSource struct {
+	base oauth2.TokenSource
+
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+	maxElapsedTime  time.Duration
+
+	// sleep is overridable so the backoff loop can be unit tested without
+	// actually waiting.
+	sleep func(time.Duration)
+}
+
+// newRetryingTokenSource wraps base with exponential-backoff retries. A
+// maxElapsedTime <= 0 does not mean "retry forever": it is replaced with
+// defaultRetryMaxElapsed, so a persistently failing IdP can't hang the
+// caller indefinitely.
+func newRetryingTokenSource(base oauth2.TokenSource, maxElapsedTime time.Duration) *retryingTokenSource {
+	if maxElapsedTime <= 0 {
+		maxElapsedTime = defaultRetryMaxElapsed
+	}
+	return &retryingTokenSource{
+		base:            base,
+		initialInterval: defaultRetryInitialInterval,
+		maxInterval:     defaultRetryMaxInterval,
+		multiplier:      defaultRetryMultiplier,
+		maxElapsedTime:  maxElapsedTime,
+		sleep:           time.Sleep,
+	}
+}
+
+// Token fetches a token from the wrapped TokenSource, retrying with
+// exponential backoff while the failure is retryable and the retry budget
+// (RetryMaxElapsed, or defaultRetryMaxElapsed when that is unset) has not
+// been exhausted.
+func (s *retryingTokenSource) Token() (*oauth2.Token, error) {
+	start := time.Now()
+	interval := s.initialInterval
+	var lastErr error
+	for {
+		tok, err := s.base.Token()
+		if err == nil {
+			return tok, nil
+		}
+		lastErr = err
+
+		retryable, retryAfter := classifyTokenError(err)
+		if !retryable {
+			return nil, err
+		}
+
+		wait := interval
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		if s.maxElapsedTime > 0 && time.Since(start)+wait > s.maxElapsedTime {
+			return nil, fmt.Errorf("giving up fetching oauth2 token after %s: %w", time.Since(start).Round(time.Millisecond), lastErr)
+		}
+
+		s.sleep(wait)
+		interval = time.Duration(float64(interval) * s.multiplier)
+		if interval > s.maxInterval {
+			interval = s.maxInterval
+		}
+	}
+}
+
+// classifyTokenError decides whether a failed token fetch is worth retrying,
+// and how long to wait before the next attempt (0 meaning "use the regular
+// backoff interval").
+func classifyTokenError(err error) (retryable bool, retryAfter time.Duration) {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		if retrieveErr.Response == nil {
+			return false, 0
+		}
+		code := retrieveErr.Response.StatusCode
+		if code != http.StatusTooManyRequests && code < http.StatusInternalServerError {
+			return false, 0
+		}
+		return true, parseRetryAfter(retrieveErr.Response)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true, 0
+	}
+	return false, 0
+}
+
+// parseRetryAfter returns the duration indicated by a Retry-After header, or
+// 0 if the header is absent or unparsable.
+func parseRetryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
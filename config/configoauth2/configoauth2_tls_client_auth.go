@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configoauth2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var (
+	errNoClientIDProvidedTLS = errors.New("no ClientID provided in OAuth2 tls_client_auth configuration")
+	errNoTokenURLProvidedTLS = errors.New("no TokenURL provided in OAuth2 tls_client_auth configuration")
+	errNoClientCertProvided  = errors.New("tls_client_auth requires TLSSetting.CertFile/KeyFile to present a client certificate")
+)
+
+// TLSClientAuthConfig stores the configuration for the OAuth2
+// client_credentials flow authenticated with mutual TLS instead of a client
+// secret (RFC 8705 "tls_client_auth"/"self_signed_tls_client_auth"). The
+// client certificate that identifies the caller to the token endpoint is
+// configured through TLSSetting.
+type TLSClientAuthConfig struct {
+	// ClientID is the application's ID. No ClientSecret is sent; the client
+	// certificate negotiated in TLSSetting identifies the client instead.
+	ClientID string `mapstructure:"client_id"`
+
+	// TokenURL is the resource server's token endpoint URL.
+	TokenURL string `mapstructure:"token_url"`
+
+	// Scopes specifies optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+
+	// EndpointParams carries extra token request parameters, e.g. "audience"
+	// or "resource" for Azure AD / AWS Cognito style IdPs.
+	EndpointParams EndpointParams `mapstructure:"endpoint_params,omitempty"`
+
+	// TLSSetting configures the mutual TLS connection to TokenURL. CertFile
+	// and KeyFile must be set so the token endpoint can authenticate the
+	// client from its certificate.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// RetryMaxElapsed is the maximum total time spent retrying a token fetch
+	// before giving up. A value <= 0 falls back to a sane default rather
+	// than retrying forever.
+	RetryMaxElapsed time.Duration `mapstructure:"retry_max_elapsed_time"`
+
+	// TokenCacheDir, if non-empty, enables on-disk caching of the fetched
+	// token, see OAuth2ClientCredentials.TokenCacheDir.
+	TokenCacheDir string `mapstructure:"token_cache_dir"`
+}
+
+// TokenSource returns an oauth2.TokenSource that fetches tokens using the
+// client_credentials grant over a mutually-authenticated TLS connection,
+// with no client secret sent.
+func (c *TLSClientAuthConfig) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.ClientID == "" {
+		return nil, errNoClientIDProvidedTLS
+	}
+	if c.TokenURL == "" {
+		return nil, errNoTokenURLProvidedTLS
+	}
+	if c.TLSSetting.CertFile == "" || c.TLSSetting.KeyFile == "" {
+		return nil, errNoClientCertProvided
+	}
+
+	tlsCfg, err := c.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, newTokenHTTPClient(tlsCfg))
+
+	cfg := clientcredentials.Config{
+		ClientID:       c.ClientID,
+		TokenURL:       c.TokenURL,
+		Scopes:         c.Scopes,
+		EndpointParams: c.EndpointParams.toURLValues(),
+		AuthStyle:      oauth2.AuthStyleInParams,
+	}
+
+	var source oauth2.TokenSource = newRetryingTokenSource(cfg.TokenSource(ctx), c.RetryMaxElapsed)
+	if c.TokenCacheDir != "" {
+		source = newCachingTokenSource(source, c.TokenCacheDir, c.ClientID, c.TokenURL, c.Scopes)
+	}
+	return source, nil
+}
@@ -223,6 +223,8 @@ func (md Metrics) MetricAndDataPointCount() (metricCount int, dataPointCount int
 					dataPointCount += m.Histogram().DataPoints().Len()
 				case MetricDataTypeSummary:
 					dataPointCount += m.Summary().DataPoints().Len()
+				case MetricDataTypeExponentialHistogram:
+					dataPointCount += m.ExponentialHistogram().DataPoints().Len()
 				}
 			}
 		}
@@ -242,6 +244,7 @@ const (
 	MetricDataTypeIntHistogram
 	MetricDataTypeHistogram
 	MetricDataTypeSummary
+	MetricDataTypeExponentialHistogram
 )
 
 // String returns the string representation of the MetricDataType.
@@ -263,6 +266,8 @@ func (mdt MetricDataType) String() string {
 		return "Histogram"
 	case MetricDataTypeSummary:
 		return "Summary"
+	case MetricDataTypeExponentialHistogram:
+		return "ExponentialHistogram"
 	}
 	return ""
 }
@@ -285,6 +290,8 @@ func (ms Metric) DataType() MetricDataType {
 		return MetricDataTypeHistogram
 	case *otlpmetrics.Metric_DoubleSummary:
 		return MetricDataTypeSummary
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		return MetricDataTypeExponentialHistogram
 	}
 	return MetricDataTypeNone
 }
@@ -307,6 +314,8 @@ func (ms Metric) SetDataType(ty MetricDataType) {
 		ms.orig.Data = &otlpmetrics.Metric_DoubleHistogram{DoubleHistogram: &otlpmetrics.DoubleHistogram{}}
 	case MetricDataTypeSummary:
 		ms.orig.Data = &otlpmetrics.Metric_DoubleSummary{DoubleSummary: &otlpmetrics.DoubleSummary{}}
+	case MetricDataTypeExponentialHistogram:
+		ms.orig.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{}}
 	}
 }
 
@@ -359,6 +368,13 @@ func (ms Metric) Summary() Summary {
 	return newSummary(ms.orig.Data.(*otlpmetrics.Metric_DoubleSummary).DoubleSummary)
 }
 
+// ExponentialHistogram returns the data as ExponentialHistogram.
+// Calling this function when DataType() != MetricDataTypeExponentialHistogram will cause a panic.
+// Calling this function on zero-initialized Metric will cause a panic.
+func (ms Metric) ExponentialHistogram() ExponentialHistogram {
+	return newExponentialHistogram(ms.orig.Data.(*otlpmetrics.Metric_ExponentialHistogram).ExponentialHistogram)
+}
+
 func copyData(src, dest *otlpmetrics.Metric) {
 	switch srcData := (src).Data.(type) {
 	case *otlpmetrics.Metric_IntGauge:
@@ -389,6 +405,10 @@ func copyData(src, dest *otlpmetrics.Metric) {
 		data := &otlpmetrics.Metric_DoubleSummary{DoubleSummary: &otlpmetrics.DoubleSummary{}}
 		newSummary(srcData.DoubleSummary).CopyTo(newSummary(data.DoubleSummary))
 		dest.Data = data
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		data := &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{}}
+		newExponentialHistogram(srcData.ExponentialHistogram).CopyTo(newExponentialHistogram(data.ExponentialHistogram))
+		dest.Data = data
 	}
 }
 
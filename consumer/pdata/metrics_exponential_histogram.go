@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import "math"
+
+// ExponentialHistogramBucketIndex returns the index into Buckets.BucketCounts
+// that a positive value v falls into for an exponential histogram recorded
+// at the given scale and offset, following the mapping defined by the
+// OpenTelemetry metrics data model: index = floor(log2(v) * 2^scale) - offset.
+//
+// v must be > 0; zero and negative values are not bucketed by this function
+// and are instead accounted for by ZeroCount or the Negative Buckets.
+func ExponentialHistogramBucketIndex(v float64, scale int32, offset int32) int32 {
+	return int32(math.Floor(math.Log2(v)*math.Exp2(float64(scale)))) - offset
+}
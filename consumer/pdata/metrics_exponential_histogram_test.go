@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import "testing"
+
+func TestExponentialHistogramBucketIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      float64
+		scale  int32
+		offset int32
+		want   int32
+	}{
+		{name: "scale zero, no offset", v: 4, scale: 0, offset: 0, want: 2},
+		{name: "scale zero, with offset", v: 4, scale: 0, offset: 1, want: 1},
+		{name: "positive scale", v: 4, scale: 2, offset: 0, want: 8},
+		{name: "negative scale", v: 4, scale: -1, offset: 0, want: 1},
+		{name: "value between bucket boundaries", v: 3, scale: 0, offset: 0, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExponentialHistogramBucketIndex(tt.v, tt.scale, tt.offset)
+			if got != tt.want {
+				t.Errorf("ExponentialHistogramBucketIndex(%v, %v, %v) = %v, want %v", tt.v, tt.scale, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
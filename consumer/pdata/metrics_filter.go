@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+// RemoveIf calls f sequentially for each element present in the slice. If f
+// returns true, the element is removed from the slice.
+func (es IntDataPointSlice) RemoveIf(f func(IntDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < es.Len(); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// RemoveIf calls f sequentially for each element present in the slice. If f
+// returns true, the element is removed from the slice.
+func (es DoubleDataPointSlice) RemoveIf(f func(DoubleDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < es.Len(); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// RemoveIf calls f sequentially for each element present in the slice. If f
+// returns true, the element is removed from the slice.
+func (es IntHistogramDataPointSlice) RemoveIf(f func(IntHistogramDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < es.Len(); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// RemoveIf calls f sequentially for each element present in the slice. If f
+// returns true, the element is removed from the slice.
+func (es HistogramDataPointSlice) RemoveIf(f func(HistogramDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < es.Len(); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// RemoveIf calls f sequentially for each element present in the slice. If f
+// returns true, the element is removed from the slice.
+func (es MetricSlice) RemoveIf(f func(Metric) bool) {
+	newLen := 0
+	for i := 0; i < es.Len(); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// RemoveIf calls f sequentially for each element present in the slice. If f
+// returns true, the element is removed from the slice.
+func (es ExponentialHistogramDataPointSlice) RemoveIf(f func(ExponentialHistogramDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < es.Len(); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsutil
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type intHistogramState struct {
+	count        uint64
+	sum          int64
+	bucketCounts []uint64
+	timestamp    pdata.Timestamp
+}
+
+type histogramState struct {
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+	timestamp    pdata.Timestamp
+}
+
+type exponentialHistogramState struct {
+	count     uint64
+	sum       float64
+	zeroCount uint64
+	scale     int32
+	positive  bucketsState
+	negative  bucketsState
+	timestamp pdata.Timestamp
+}
+
+type bucketsState struct {
+	offset       int32
+	bucketCounts []uint64
+}
+
+func (c *TemporalityConverter) convertIntHistogram(resource pdata.Resource, ilName, ilVersion, metricName string, hist pdata.IntHistogram, target pdata.AggregationTemporality, now time.Time) bool {
+	if hist.AggregationTemporality() == target {
+		return false
+	}
+	dps := hist.DataPoints()
+	dps.RemoveIf(func(dp pdata.IntHistogramDataPoint) bool {
+		key := seriesKeyOf(resource, ilName, ilVersion, metricName, dp.LabelsMap())
+		return c.stepIntHistogram(key, dp, target, now)
+	})
+	hist.SetAggregationTemporality(target)
+	return dps.Len() == 0
+}
+
+func (c *TemporalityConverter) stepIntHistogram(key seriesKey, dp pdata.IntHistogramDataPoint, target pdata.AggregationTemporality, now time.Time) (drop bool) {
+	prior, ok := c.store.get(key, now)
+	current := intHistogramState{
+		count:        dp.Count(),
+		sum:          dp.Sum(),
+		bucketCounts: append([]uint64(nil), dp.BucketCounts()...),
+		timestamp:    dp.Timestamp(),
+	}
+
+	if target == pdata.AggregationTemporalityDelta {
+		if !ok {
+			c.store.put(key, current, now)
+			return true
+		}
+		prev := prior.(intHistogramState)
+		if current.count < prev.count || len(current.bucketCounts) != len(prev.bucketCounts) || bucketCountsRegressed(current.bucketCounts, prev.bucketCounts) {
+			c.store.put(key, current, now)
+			return false
+		}
+		dp.SetStartTimestamp(prev.timestamp)
+		dp.SetCount(current.count - prev.count)
+		dp.SetSum(current.sum - prev.sum)
+		dp.SetBucketCounts(diffBucketCounts(current.bucketCounts, prev.bucketCounts))
+		c.store.put(key, current, now)
+		return false
+	}
+
+	if !ok {
+		c.store.put(key, current, now)
+		return false
+	}
+	prev := prior.(intHistogramState)
+	if len(current.bucketCounts) != len(prev.bucketCounts) {
+		c.store.put(key, current, now)
+		return false
+	}
+	cumulative := intHistogramState{
+		count:        prev.count + current.count,
+		sum:          prev.sum + current.sum,
+		bucketCounts: make([]uint64, len(current.bucketCounts)),
+		timestamp:    current.timestamp,
+	}
+	for i := range current.bucketCounts {
+		cumulative.bucketCounts[i] = prev.bucketCounts[i] + current.bucketCounts[i]
+	}
+	dp.SetCount(cumulative.count)
+	dp.SetSum(cumulative.sum)
+	dp.SetBucketCounts(cumulative.bucketCounts)
+	c.store.put(key, cumulative, now)
+	return false
+}
+
+func (c *TemporalityConverter) convertHistogram(resource pdata.Resource, ilName, ilVersion, metricName string, hist pdata.Histogram, target pdata.AggregationTemporality, now time.Time) bool {
+	if hist.AggregationTemporality() == target {
+		return false
+	}
+	dps := hist.DataPoints()
+	dps.RemoveIf(func(dp pdata.HistogramDataPoint) bool {
+		key := seriesKeyOf(resource, ilName, ilVersion, metricName, dp.LabelsMap())
+		return c.stepHistogram(key, dp, target, now)
+	})
+	hist.SetAggregationTemporality(target)
+	return dps.Len() == 0
+}
+
+func (c *TemporalityConverter) stepHistogram(key seriesKey, dp pdata.HistogramDataPoint, target pdata.AggregationTemporality, now time.Time) (drop bool) {
+	prior, ok := c.store.get(key, now)
+	current := histogramState{
+		count:        dp.Count(),
+		sum:          dp.Sum(),
+		bucketCounts: append([]uint64(nil), dp.BucketCounts()...),
+		timestamp:    dp.Timestamp(),
+	}
+
+	if target == pdata.AggregationTemporalityDelta {
+		if !ok {
+			c.store.put(key, current, now)
+			return true
+		}
+		prev := prior.(histogramState)
+		if current.count < prev.count || len(current.bucketCounts) != len(prev.bucketCounts) || bucketCountsRegressed(current.bucketCounts, prev.bucketCounts) {
+			c.store.put(key, current, now)
+			return false
+		}
+		dp.SetStartTimestamp(prev.timestamp)
+		dp.SetCount(current.count - prev.count)
+		dp.SetSum(current.sum - prev.sum)
+		dp.SetBucketCounts(diffBucketCounts(current.bucketCounts, prev.bucketCounts))
+		c.store.put(key, current, now)
+		return false
+	}
+
+	if !ok {
+		c.store.put(key, current, now)
+		return false
+	}
+	prev := prior.(histogramState)
+	if len(current.bucketCounts) != len(prev.bucketCounts) {
+		c.store.put(key, current, now)
+		return false
+	}
+	cumulative := histogramState{
+		count:        prev.count + current.count,
+		sum:          prev.sum + current.sum,
+		bucketCounts: make([]uint64, len(current.bucketCounts)),
+		timestamp:    current.timestamp,
+	}
+	for i := range current.bucketCounts {
+		cumulative.bucketCounts[i] = prev.bucketCounts[i] + current.bucketCounts[i]
+	}
+	dp.SetCount(cumulative.count)
+	dp.SetSum(cumulative.sum)
+	dp.SetBucketCounts(cumulative.bucketCounts)
+	c.store.put(key, cumulative, now)
+	return false
+}
+
+// convertExponentialHistogram converts a single ExponentialHistogram's data
+// points. A change in scale (and therefore bucket boundaries) between two
+// observations of the same series is treated the same way as a counter
+// reset: the new point is emitted as-is and becomes the new baseline, since
+// the old and new buckets are not directly comparable.
+func (c *TemporalityConverter) convertExponentialHistogram(resource pdata.Resource, ilName, ilVersion, metricName string, hist pdata.ExponentialHistogram, target pdata.AggregationTemporality, now time.Time) bool {
+	if hist.AggregationTemporality() == target {
+		return false
+	}
+	dps := hist.DataPoints()
+	dps.RemoveIf(func(dp pdata.ExponentialHistogramDataPoint) bool {
+		key := seriesKeyOf(resource, ilName, ilVersion, metricName, dp.LabelsMap())
+		return c.stepExponentialHistogram(key, dp, target, now)
+	})
+	hist.SetAggregationTemporality(target)
+	return dps.Len() == 0
+}
+
+func (c *TemporalityConverter) stepExponentialHistogram(key seriesKey, dp pdata.ExponentialHistogramDataPoint, target pdata.AggregationTemporality, now time.Time) (drop bool) {
+	prior, ok := c.store.get(key, now)
+	current := exponentialHistogramState{
+		count:     dp.Count(),
+		sum:       dp.Sum(),
+		zeroCount: dp.ZeroCount(),
+		scale:     dp.Scale(),
+		positive:  bucketsState{offset: dp.Positive().Offset(), bucketCounts: append([]uint64(nil), dp.Positive().BucketCounts()...)},
+		negative:  bucketsState{offset: dp.Negative().Offset(), bucketCounts: append([]uint64(nil), dp.Negative().BucketCounts()...)},
+		timestamp: dp.Timestamp(),
+	}
+
+	if target == pdata.AggregationTemporalityDelta {
+		if !ok {
+			c.store.put(key, current, now)
+			return true
+		}
+		prev := prior.(exponentialHistogramState)
+		if current.count < prev.count || !bucketLayoutsMatch(current, prev) ||
+			current.zeroCount < prev.zeroCount ||
+			bucketCountsRegressed(current.positive.bucketCounts, prev.positive.bucketCounts) ||
+			bucketCountsRegressed(current.negative.bucketCounts, prev.negative.bucketCounts) {
+			c.store.put(key, current, now)
+			return false
+		}
+		dp.SetStartTimestamp(prev.timestamp)
+		dp.SetCount(current.count - prev.count)
+		dp.SetSum(current.sum - prev.sum)
+		dp.SetZeroCount(current.zeroCount - prev.zeroCount)
+		dp.Positive().SetBucketCounts(diffBucketCounts(current.positive.bucketCounts, prev.positive.bucketCounts))
+		dp.Negative().SetBucketCounts(diffBucketCounts(current.negative.bucketCounts, prev.negative.bucketCounts))
+		c.store.put(key, current, now)
+		return false
+	}
+
+	if !ok {
+		c.store.put(key, current, now)
+		return false
+	}
+	prev := prior.(exponentialHistogramState)
+	if !bucketLayoutsMatch(current, prev) {
+		c.store.put(key, current, now)
+		return false
+	}
+	cumulative := exponentialHistogramState{
+		count:     prev.count + current.count,
+		sum:       prev.sum + current.sum,
+		zeroCount: prev.zeroCount + current.zeroCount,
+		scale:     current.scale,
+		positive:  bucketsState{offset: current.positive.offset, bucketCounts: sumBucketCounts(current.positive.bucketCounts, prev.positive.bucketCounts)},
+		negative:  bucketsState{offset: current.negative.offset, bucketCounts: sumBucketCounts(current.negative.bucketCounts, prev.negative.bucketCounts)},
+		timestamp: current.timestamp,
+	}
+	dp.SetCount(cumulative.count)
+	dp.SetSum(cumulative.sum)
+	dp.SetZeroCount(cumulative.zeroCount)
+	dp.Positive().SetBucketCounts(cumulative.positive.bucketCounts)
+	dp.Negative().SetBucketCounts(cumulative.negative.bucketCounts)
+	c.store.put(key, cumulative, now)
+	return false
+}
+
+func bucketLayoutsMatch(a, b exponentialHistogramState) bool {
+	return a.scale == b.scale &&
+		a.positive.offset == b.positive.offset && len(a.positive.bucketCounts) == len(b.positive.bucketCounts) &&
+		a.negative.offset == b.negative.offset && len(a.negative.bucketCounts) == len(b.negative.bucketCounts)
+}
+
+// bucketCountsRegressed reports whether any bucket in current holds a lower
+// count than the corresponding bucket in prev. current and prev must already
+// be known to have the same length. Subtracting a regressed bucket pairwise
+// would wrap a uint64 around to a huge value instead of signalling a reset,
+// so callers treat a true result the same as an overall count decrease.
+func bucketCountsRegressed(current, prev []uint64) bool {
+	for i := range current {
+		if current[i] < prev[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func diffBucketCounts(current, prev []uint64) []uint64 {
+	out := make([]uint64, len(current))
+	for i := range current {
+		out[i] = current[i] - prev[i]
+	}
+	return out
+}
+
+func sumBucketCounts(current, prev []uint64) []uint64 {
+	out := make([]uint64, len(current))
+	for i := range current {
+		out[i] = current[i] + prev[i]
+	}
+	return out
+}
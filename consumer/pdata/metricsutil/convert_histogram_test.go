@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newIntHistogramMetrics(metricName string, temporality pdata.AggregationTemporality, ts pdata.Timestamp, count uint64, sum int64, bucketCounts []uint64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName(metricName)
+	m.SetDataType(pdata.MetricDataTypeIntHistogram)
+	hist := m.IntHistogram()
+	hist.SetAggregationTemporality(temporality)
+
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetBucketCounts(bucketCounts)
+	return md
+}
+
+func firstIntHistogramDataPoint(t *testing.T, md pdata.Metrics) (pdata.IntHistogramDataPoint, bool) {
+	t.Helper()
+	rms := md.ResourceMetrics()
+	if rms.Len() == 0 {
+		return pdata.IntHistogramDataPoint{}, false
+	}
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	if metrics.Len() == 0 {
+		return pdata.IntHistogramDataPoint{}, false
+	}
+	dps := metrics.At(0).IntHistogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	return dps.At(0), true
+}
+
+func TestConvertIntHistogramToDelta_SecondPointIsDifference(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 1000, 10, 100, []uint64{2, 3, 5})
+	c.ToDelta(first)
+
+	second := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 2000, 16, 150, []uint64{3, 5, 8})
+	out := c.ToDelta(second)
+
+	dp, ok := firstIntHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 6, dp.Count())
+	assert.EqualValues(t, 50, dp.Sum())
+	assert.Equal(t, []uint64{1, 2, 3}, dp.BucketCounts())
+}
+
+func TestConvertIntHistogramToDelta_CounterResetEmitsRawValue(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 1000, 10, 100, []uint64{2, 3, 5})
+	c.ToDelta(first)
+
+	// Process restart: the aggregate count goes back down.
+	reset := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 2000, 4, 40, []uint64{1, 1, 2})
+	out := c.ToDelta(reset)
+
+	dp, ok := firstIntHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 4, dp.Count())
+	assert.Equal(t, []uint64{1, 1, 2}, dp.BucketCounts())
+}
+
+func TestConvertIntHistogramToDelta_SingleBucketRegressionTreatedAsReset(t *testing.T) {
+	// The aggregate count still climbs, but one bucket goes backwards, as a
+	// buggy/re-aggregating upstream exporter might produce. Naive pairwise
+	// subtraction would wrap this bucket's uint64 delta around to a huge
+	// value instead of detecting the reset.
+	c := NewTemporalityConverter()
+	first := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 1000, 10, 100, []uint64{2, 8})
+	c.ToDelta(first)
+
+	regressed := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 2000, 12, 110, []uint64{5, 3})
+	out := c.ToDelta(regressed)
+
+	dp, ok := firstIntHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 12, dp.Count(), "a regressed bucket must be treated as a reset and emitted as-is")
+	assert.Equal(t, []uint64{5, 3}, dp.BucketCounts())
+}
+
+func TestConvertIntHistogramToDelta_BucketLayoutChangeTreatedAsReset(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 1000, 10, 100, []uint64{2, 3, 5})
+	c.ToDelta(first)
+
+	// The exporter changed its bucket boundaries: a different number of
+	// buckets is not comparable to the previous layout.
+	relayout := newIntHistogramMetrics("latency", pdata.AggregationTemporalityCumulative, 2000, 12, 120, []uint64{4, 4, 2, 2})
+	out := c.ToDelta(relayout)
+
+	dp, ok := firstIntHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 12, dp.Count())
+	assert.Equal(t, []uint64{4, 4, 2, 2}, dp.BucketCounts())
+}
+
+func TestConvertIntHistogramToCumulative_Accumulates(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntHistogramMetrics("latency", pdata.AggregationTemporalityDelta, 1000, 2, 20, []uint64{1, 1})
+	out1 := c.ToCumulative(first)
+	dp1, ok := firstIntHistogramDataPoint(t, out1)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, dp1.Count())
+
+	second := newIntHistogramMetrics("latency", pdata.AggregationTemporalityDelta, 2000, 3, 30, []uint64{2, 1})
+	out2 := c.ToCumulative(second)
+	dp2, ok := firstIntHistogramDataPoint(t, out2)
+	require.True(t, ok)
+	assert.EqualValues(t, 5, dp2.Count())
+	assert.EqualValues(t, 50, dp2.Sum())
+	assert.Equal(t, []uint64{3, 2}, dp2.BucketCounts())
+}
+
+func newDoubleSumMetrics(metricName string, temporality pdata.AggregationTemporality, ts pdata.Timestamp, value float64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName(metricName)
+	m.SetDataType(pdata.MetricDataTypeDoubleSum)
+	sum := m.DoubleSum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetValue(value)
+	return md
+}
+
+func firstDoubleSumDataPoint(t *testing.T, md pdata.Metrics) (pdata.DoubleDataPoint, bool) {
+	t.Helper()
+	rms := md.ResourceMetrics()
+	if rms.Len() == 0 {
+		return pdata.DoubleDataPoint{}, false
+	}
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	if metrics.Len() == 0 {
+		return pdata.DoubleDataPoint{}, false
+	}
+	dps := metrics.At(0).DoubleSum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	return dps.At(0), true
+}
+
+func TestConvertDoubleSumToDelta_CounterResetEmitsRawValue(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newDoubleSumMetrics("cpu.seconds", pdata.AggregationTemporalityCumulative, 1000, 10.5)
+	c.ToDelta(first)
+
+	reset := newDoubleSumMetrics("cpu.seconds", pdata.AggregationTemporalityCumulative, 2000, 1.5)
+	out := c.ToDelta(reset)
+
+	dp, ok := firstDoubleSumDataPoint(t, out)
+	require.True(t, ok)
+	assert.InDelta(t, 1.5, dp.Value(), 0.0001)
+}
+
+func newExponentialHistogramMetrics(metricName string, temporality pdata.AggregationTemporality, ts pdata.Timestamp, scale int32, zeroCount uint64, count uint64, positiveCounts []uint64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName(metricName)
+	m.SetDataType(pdata.MetricDataTypeExponentialHistogram)
+	hist := m.ExponentialHistogram()
+	hist.SetAggregationTemporality(temporality)
+
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetScale(scale)
+	dp.SetZeroCount(zeroCount)
+	dp.SetCount(count)
+	dp.Positive().SetOffset(0)
+	dp.Positive().SetBucketCounts(positiveCounts)
+	return md
+}
+
+func firstExponentialHistogramDataPoint(t *testing.T, md pdata.Metrics) (pdata.ExponentialHistogramDataPoint, bool) {
+	t.Helper()
+	rms := md.ResourceMetrics()
+	if rms.Len() == 0 {
+		return pdata.ExponentialHistogramDataPoint{}, false
+	}
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	if metrics.Len() == 0 {
+		return pdata.ExponentialHistogramDataPoint{}, false
+	}
+	dps := metrics.At(0).ExponentialHistogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	return dps.At(0), true
+}
+
+func TestConvertExponentialHistogramToDelta_SecondPointIsDifference(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newExponentialHistogramMetrics("latency.exp", pdata.AggregationTemporalityCumulative, 1000, 2, 1, 10, []uint64{2, 3})
+	c.ToDelta(first)
+
+	second := newExponentialHistogramMetrics("latency.exp", pdata.AggregationTemporalityCumulative, 2000, 2, 2, 16, []uint64{4, 5})
+	out := c.ToDelta(second)
+
+	dp, ok := firstExponentialHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 6, dp.Count())
+	assert.EqualValues(t, 1, dp.ZeroCount())
+	assert.Equal(t, []uint64{2, 2}, dp.Positive().BucketCounts())
+}
+
+func TestConvertExponentialHistogramToDelta_SingleBucketRegressionTreatedAsReset(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newExponentialHistogramMetrics("latency.exp", pdata.AggregationTemporalityCumulative, 1000, 2, 1, 10, []uint64{6, 2})
+	c.ToDelta(first)
+
+	// Overall count still rises, but the first bucket regresses.
+	regressed := newExponentialHistogramMetrics("latency.exp", pdata.AggregationTemporalityCumulative, 2000, 2, 1, 12, []uint64{3, 7})
+	out := c.ToDelta(regressed)
+
+	dp, ok := firstExponentialHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 12, dp.Count(), "a regressed bucket must be treated as a reset and emitted as-is")
+	assert.Equal(t, []uint64{3, 7}, dp.Positive().BucketCounts())
+}
+
+func TestConvertExponentialHistogramToDelta_ScaleChangeTreatedAsReset(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newExponentialHistogramMetrics("latency.exp", pdata.AggregationTemporalityCumulative, 1000, 2, 1, 10, []uint64{2, 3})
+	c.ToDelta(first)
+
+	// The exporter re-scaled its bucket boundaries between observations.
+	rescaled := newExponentialHistogramMetrics("latency.exp", pdata.AggregationTemporalityCumulative, 2000, 3, 1, 12, []uint64{2, 3})
+	out := c.ToDelta(rescaled)
+
+	dp, ok := firstExponentialHistogramDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 12, dp.Count())
+	assert.Equal(t, []uint64{2, 3}, dp.Positive().BucketCounts())
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsutil
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type intSumState struct {
+	value     int64
+	timestamp pdata.Timestamp
+}
+
+type doubleSumState struct {
+	value     float64
+	timestamp pdata.Timestamp
+}
+
+func (c *TemporalityConverter) convertIntSum(resource pdata.Resource, ilName, ilVersion, metricName string, sum pdata.IntSum, target pdata.AggregationTemporality, now time.Time) bool {
+	if !sum.IsMonotonic() || sum.AggregationTemporality() == target {
+		return false
+	}
+	dps := sum.DataPoints()
+	dps.RemoveIf(func(dp pdata.IntDataPoint) bool {
+		key := seriesKeyOf(resource, ilName, ilVersion, metricName, dp.LabelsMap())
+		return c.stepIntSum(key, dp, target, now)
+	})
+	sum.SetAggregationTemporality(target)
+	return dps.Len() == 0
+}
+
+func (c *TemporalityConverter) stepIntSum(key seriesKey, dp pdata.IntDataPoint, target pdata.AggregationTemporality, now time.Time) (drop bool) {
+	prior, ok := c.store.get(key, now)
+	current := intSumState{value: dp.Value(), timestamp: dp.Timestamp()}
+
+	if target == pdata.AggregationTemporalityDelta {
+		if !ok {
+			c.store.put(key, current, now)
+			return true
+		}
+		prev := prior.(intSumState)
+		if current.value < prev.value {
+			// Counter reset: emit the new cumulative value as-is rather than
+			// guessing at a delta, and reset state to the new baseline.
+			c.store.put(key, current, now)
+			return false
+		}
+		dp.SetStartTimestamp(prev.timestamp)
+		dp.SetValue(current.value - prev.value)
+		c.store.put(key, current, now)
+		return false
+	}
+
+	// target == Cumulative
+	if !ok {
+		c.store.put(key, current, now)
+		return false
+	}
+	prev := prior.(intSumState)
+	cumulative := prev.value + current.value
+	dp.SetValue(cumulative)
+	c.store.put(key, intSumState{value: cumulative, timestamp: current.timestamp}, now)
+	return false
+}
+
+func (c *TemporalityConverter) convertDoubleSum(resource pdata.Resource, ilName, ilVersion, metricName string, sum pdata.DoubleSum, target pdata.AggregationTemporality, now time.Time) bool {
+	if !sum.IsMonotonic() || sum.AggregationTemporality() == target {
+		return false
+	}
+	dps := sum.DataPoints()
+	dps.RemoveIf(func(dp pdata.DoubleDataPoint) bool {
+		key := seriesKeyOf(resource, ilName, ilVersion, metricName, dp.LabelsMap())
+		return c.stepDoubleSum(key, dp, target, now)
+	})
+	sum.SetAggregationTemporality(target)
+	return dps.Len() == 0
+}
+
+func (c *TemporalityConverter) stepDoubleSum(key seriesKey, dp pdata.DoubleDataPoint, target pdata.AggregationTemporality, now time.Time) (drop bool) {
+	prior, ok := c.store.get(key, now)
+	current := doubleSumState{value: dp.Value(), timestamp: dp.Timestamp()}
+
+	if target == pdata.AggregationTemporalityDelta {
+		if !ok {
+			c.store.put(key, current, now)
+			return true
+		}
+		prev := prior.(doubleSumState)
+		if current.value < prev.value {
+			c.store.put(key, current, now)
+			return false
+		}
+		dp.SetStartTimestamp(prev.timestamp)
+		dp.SetValue(current.value - prev.value)
+		c.store.put(key, current, now)
+		return false
+	}
+
+	if !ok {
+		c.store.put(key, current, now)
+		return false
+	}
+	prev := prior.(doubleSumState)
+	cumulative := prev.value + current.value
+	dp.SetValue(cumulative)
+	c.store.put(key, doubleSumState{value: cumulative, timestamp: current.timestamp}, now)
+	return false
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsutil
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// seriesKey uniquely identifies a timeseries within a pdata.Metrics payload:
+// the owning resource, instrumentation library, metric name, and attribute
+// (label) set. It is deliberately a string so it can be used as a map key and
+// compared cheaply.
+type seriesKey string
+
+// seriesKeyOf builds the seriesKey for a data point belonging to the given
+// resource, instrumentation library and metric, hashing its label set.
+//
+// Attribute-set hashing is stable across calls: labels are sorted by key
+// before being joined, so the same label set always produces the same key
+// regardless of insertion order.
+func seriesKeyOf(resource pdata.Resource, ilName, ilVersion, metricName string, labels pdata.StringMap) seriesKey {
+	var sb strings.Builder
+	sb.WriteString(resourceFingerprint(resource))
+	sb.WriteByte('\x00')
+	sb.WriteString(ilName)
+	sb.WriteByte('\x00')
+	sb.WriteString(ilVersion)
+	sb.WriteByte('\x00')
+	sb.WriteString(metricName)
+	sb.WriteByte('\x00')
+	sb.WriteString(labelFingerprint(labels))
+	return seriesKey(sb.String())
+}
+
+// resourceFingerprint returns a stable string representation of a Resource's
+// attribute set, used as part of a seriesKey.
+func resourceFingerprint(resource pdata.Resource) string {
+	return labelFingerprint(resource.Attributes())
+}
+
+// labelFingerprint returns a stable string representation of a StringMap,
+// sorted by key so the result does not depend on iteration order.
+func labelFingerprint(m pdata.StringMap) string {
+	kvs := make([]string, 0, m.Len())
+	m.ForEach(func(k, v string) {
+		kvs = append(kvs, k+"="+v)
+	})
+	sort.Strings(kvs)
+	return strings.Join(kvs, ",")
+}
+
+// seriesStore is a bounded, TTL-aware cache of per-series state, evicting the
+// least-recently-used series once MaxSeries is exceeded.
+type seriesStore struct {
+	maxSeries int
+	ttl       time.Duration
+
+	ll      *list.List // front = most recently used
+	entries map[seriesKey]*list.Element
+}
+
+type seriesEntry struct {
+	key      seriesKey
+	state    interface{}
+	lastSeen time.Time
+}
+
+func newSeriesStore(maxSeries int, ttl time.Duration) *seriesStore {
+	return &seriesStore{
+		maxSeries: maxSeries,
+		ttl:       ttl,
+		ll:        list.New(),
+		entries:   make(map[seriesKey]*list.Element),
+	}
+}
+
+// get returns the state stored for key, if any, and marks it as recently
+// used. A series that has not been seen within the configured TTL is treated
+// as absent and is evicted.
+func (s *seriesStore) get(key seriesKey, now time.Time) (interface{}, bool) {
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*seriesEntry)
+	if s.ttl > 0 && now.Sub(entry.lastSeen) > s.ttl {
+		s.removeElement(el)
+		return nil, false
+	}
+	s.ll.MoveToFront(el)
+	return entry.state, true
+}
+
+// put stores state for key, creating or updating the entry and evicting the
+// least-recently-used series if MaxSeries is now exceeded.
+func (s *seriesStore) put(key seriesKey, state interface{}, now time.Time) {
+	if el, ok := s.entries[key]; ok {
+		entry := el.Value.(*seriesEntry)
+		entry.state = state
+		entry.lastSeen = now
+		s.ll.MoveToFront(el)
+		return
+	}
+	el := s.ll.PushFront(&seriesEntry{key: key, state: state, lastSeen: now})
+	s.entries[key] = el
+	if s.maxSeries > 0 {
+		for len(s.entries) > s.maxSeries {
+			s.removeElement(s.ll.Back())
+		}
+	}
+}
+
+func (s *seriesStore) removeElement(el *list.Element) {
+	if el == nil {
+		return
+	}
+	entry := el.Value.(*seriesEntry)
+	delete(s.entries, entry.key)
+	s.ll.Remove(el)
+}
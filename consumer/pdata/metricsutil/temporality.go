@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricsutil provides helpers for working with pdata.Metrics that
+// don't belong on the pdata types themselves, such as converting between
+// aggregation temporalities.
+package metricsutil
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// defaultMaxSeries bounds the number of distinct timeseries a
+	// TemporalityConverter will track before it starts evicting the
+	// least-recently-used ones.
+	defaultMaxSeries = 100000
+	// defaultTTL is how long a series may go unseen before its state is
+	// considered stale and dropped.
+	defaultTTL = time.Hour
+)
+
+// TemporalityConverterOption configures a TemporalityConverter.
+type TemporalityConverterOption func(*TemporalityConverter)
+
+// WithMaxSeries bounds the number of distinct timeseries that are tracked at
+// once. Once the limit is reached, the least-recently-used series is evicted
+// to make room for new ones. A value <= 0 means unbounded.
+func WithMaxSeries(maxSeries int) TemporalityConverterOption {
+	return func(c *TemporalityConverter) {
+		c.store.maxSeries = maxSeries
+	}
+}
+
+// WithTTL sets how long a series may go unseen before its prior-value state
+// is dropped. A value <= 0 disables TTL-based eviction.
+func WithTTL(ttl time.Duration) TemporalityConverterOption {
+	return func(c *TemporalityConverter) {
+		c.store.ttl = ttl
+	}
+}
+
+// TemporalityConverter converts pdata.Metrics between cumulative and delta
+// aggregation temporality, keeping per-series prior-value state across calls.
+//
+// A single TemporalityConverter is meant to be reused across every
+// pdata.Metrics payload produced by one pipeline, since the conversion is
+// inherently stateful: it needs to remember each series' previous value to
+// compute a delta, or to re-accumulate a cumulative total.
+//
+// TemporalityConverter is safe for concurrent use.
+type TemporalityConverter struct {
+	mu    sync.Mutex
+	store *seriesStore
+}
+
+// NewTemporalityConverter creates a TemporalityConverter. By default series
+// state is bounded to defaultMaxSeries entries and expires after defaultTTL
+// of inactivity; use WithMaxSeries and WithTTL to override either.
+func NewTemporalityConverter(opts ...TemporalityConverterOption) *TemporalityConverter {
+	c := &TemporalityConverter{
+		store: newSeriesStore(defaultMaxSeries, defaultTTL),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ToDelta returns a copy of md with every IntSum, DoubleSum, IntHistogram,
+// Histogram and ExponentialHistogram data point converted to delta
+// temporality, using and updating the converter's per-series state.
+//
+// Non-monotonic sums, gauges and summaries are passed through unchanged: delta
+// conversion only applies to monotonic aggregations.
+//
+// The first cumulative point observed for a series has no prior value to
+// diff against, so it is dropped rather than emitted as a bogus delta.
+func (c *TemporalityConverter) ToDelta(md pdata.Metrics) pdata.Metrics {
+	return c.convert(md, pdata.AggregationTemporalityDelta)
+}
+
+// ToCumulative returns a copy of md with every IntSum, DoubleSum, IntHistogram,
+// Histogram and ExponentialHistogram data point converted to cumulative
+// temporality, re-accumulating on top of the converter's per-series state.
+//
+// Non-monotonic sums, gauges and summaries are passed through unchanged.
+func (c *TemporalityConverter) ToCumulative(md pdata.Metrics) pdata.Metrics {
+	return c.convert(md, pdata.AggregationTemporalityCumulative)
+}
+
+func (c *TemporalityConverter) convert(md pdata.Metrics, target pdata.AggregationTemporality) pdata.Metrics {
+	out := md.Clone()
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rms := out.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			il := ilm.InstrumentationLibrary()
+			metrics := ilm.Metrics()
+
+			// Convert in place; a metric whose only data point(s) had no
+			// prior state is dropped entirely, since a first-seen cumulative
+			// point can't produce a delta.
+			metrics.RemoveIf(func(m pdata.Metric) bool {
+				return c.convertMetric(rm.Resource(), il.Name(), il.Version(), m, target, now)
+			})
+		}
+	}
+	return out
+}
+
+// convertMetric converts a single Metric's data points in place. It returns
+// true if the metric ended up with no data points left and should be dropped
+// from its parent slice (e.g. a sum whose only point was a first-seen
+// cumulative value being converted to delta).
+func (c *TemporalityConverter) convertMetric(resource pdata.Resource, ilName, ilVersion string, m pdata.Metric, target pdata.AggregationTemporality, now time.Time) bool {
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		return c.convertIntSum(resource, ilName, ilVersion, m.Name(), m.IntSum(), target, now)
+	case pdata.MetricDataTypeDoubleSum:
+		return c.convertDoubleSum(resource, ilName, ilVersion, m.Name(), m.DoubleSum(), target, now)
+	case pdata.MetricDataTypeIntHistogram:
+		return c.convertIntHistogram(resource, ilName, ilVersion, m.Name(), m.IntHistogram(), target, now)
+	case pdata.MetricDataTypeHistogram:
+		return c.convertHistogram(resource, ilName, ilVersion, m.Name(), m.Histogram(), target, now)
+	case pdata.MetricDataTypeExponentialHistogram:
+		return c.convertExponentialHistogram(resource, ilName, ilVersion, m.Name(), m.ExponentialHistogram(), target, now)
+	default:
+		// Gauges and summaries have no meaningful temporality to convert.
+		return false
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newIntSumMetrics(ilName, ilVersion, metricName string, monotonic bool, temporality pdata.AggregationTemporality, points []intPoint) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName(ilName)
+	ilm.InstrumentationLibrary().SetVersion(ilVersion)
+
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName(metricName)
+	m.SetDataType(pdata.MetricDataTypeIntSum)
+	sum := m.IntSum()
+	sum.SetIsMonotonic(monotonic)
+	sum.SetAggregationTemporality(temporality)
+
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetTimestamp(p.ts)
+		dp.SetValue(p.value)
+		for k, v := range p.labels {
+			dp.LabelsMap().InsertString(k, v)
+		}
+	}
+	return md
+}
+
+type intPoint struct {
+	ts     pdata.Timestamp
+	value  int64
+	labels map[string]string
+}
+
+func firstIntSumDataPoint(t *testing.T, md pdata.Metrics) (pdata.IntDataPoint, bool) {
+	t.Helper()
+	rms := md.ResourceMetrics()
+	if rms.Len() == 0 {
+		return pdata.IntDataPoint{}, false
+	}
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	if ilms.Len() == 0 {
+		return pdata.IntDataPoint{}, false
+	}
+	metrics := ilms.At(0).Metrics()
+	if metrics.Len() == 0 {
+		return pdata.IntDataPoint{}, false
+	}
+	dps := metrics.At(0).IntSum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	return dps.At(0), true
+}
+
+func TestTemporalityConverterToDelta_FirstPointDropped(t *testing.T) {
+	c := NewTemporalityConverter()
+	md := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 1000, value: 10},
+	})
+
+	out := c.ToDelta(md)
+
+	assert.Equal(t, 0, out.MetricCount())
+}
+
+func TestTemporalityConverterToDelta_SecondPointIsDifference(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 1000, value: 10},
+	})
+	c.ToDelta(first)
+
+	second := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 2000, value: 15},
+	})
+	out := c.ToDelta(second)
+
+	dp, ok := firstIntSumDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 5, dp.Value())
+	assert.EqualValues(t, 1000, dp.StartTimestamp())
+}
+
+func TestTemporalityConverterToDelta_CounterResetEmitsRawValue(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 1000, value: 10},
+	})
+	c.ToDelta(first)
+
+	// The process behind this series restarted: the cumulative counter went
+	// back down instead of continuing to climb.
+	reset := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 2000, value: 3},
+	})
+	out := c.ToDelta(reset)
+
+	dp, ok := firstIntSumDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 3, dp.Value())
+}
+
+func TestTemporalityConverterToDelta_OutOfOrderTimestampTreatedAsReset(t *testing.T) {
+	// TemporalityConverter has no notion of wall-clock ordering: it only
+	// compares values. A point that arrives with an earlier Timestamp than
+	// the previously stored one, but a lower value, is indistinguishable from
+	// a genuine counter reset and is handled the same way: emitted as-is and
+	// used as the new baseline.
+	c := NewTemporalityConverter()
+	first := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 5000, value: 10},
+	})
+	c.ToDelta(first)
+
+	outOfOrder := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityCumulative, []intPoint{
+		{ts: 1000, value: 4},
+	})
+	out := c.ToDelta(outOfOrder)
+
+	dp, ok := firstIntSumDataPoint(t, out)
+	require.True(t, ok)
+	assert.EqualValues(t, 4, dp.Value())
+}
+
+func TestTemporalityConverterToCumulative_Accumulates(t *testing.T) {
+	c := NewTemporalityConverter()
+	first := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityDelta, []intPoint{
+		{ts: 1000, value: 4},
+	})
+	out1 := c.ToCumulative(first)
+	dp1, ok := firstIntSumDataPoint(t, out1)
+	require.True(t, ok)
+	assert.EqualValues(t, 4, dp1.Value())
+
+	second := newIntSumMetrics("il", "v1", "requests.total", true, pdata.AggregationTemporalityDelta, []intPoint{
+		{ts: 2000, value: 6},
+	})
+	out2 := c.ToCumulative(second)
+	dp2, ok := firstIntSumDataPoint(t, out2)
+	require.True(t, ok)
+	assert.EqualValues(t, 10, dp2.Value())
+}
+
+func TestSeriesKeyOfHashingStability(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+	resource.Attributes().InsertString("service.instance.id", "abc-123")
+
+	labelsA := pdata.NewStringMap()
+	labelsA.InsertString("http.method", "GET")
+	labelsA.InsertString("http.status_code", "200")
+
+	labelsB := pdata.NewStringMap()
+	labelsB.InsertString("http.status_code", "200")
+	labelsB.InsertString("http.method", "GET")
+
+	keyA := seriesKeyOf(resource, "il", "v1", "requests.total", labelsA)
+	keyB := seriesKeyOf(resource, "il", "v1", "requests.total", labelsB)
+
+	assert.Equal(t, keyA, keyB, "label insertion order must not change the series key")
+}
+
+func TestSeriesKeyOfDistinguishesDifferentLabelSets(t *testing.T) {
+	resource := pdata.NewResource()
+
+	labelsA := pdata.NewStringMap()
+	labelsA.InsertString("http.status_code", "200")
+
+	labelsB := pdata.NewStringMap()
+	labelsB.InsertString("http.status_code", "500")
+
+	keyA := seriesKeyOf(resource, "il", "v1", "requests.total", labelsA)
+	keyB := seriesKeyOf(resource, "il", "v1", "requests.total", labelsB)
+
+	assert.NotEqual(t, keyA, keyB)
+}
+
+func TestSeriesStoreEvictsLeastRecentlyUsedBeyondMaxSeries(t *testing.T) {
+	s := newSeriesStore(2, 0)
+	now := time.Now()
+
+	s.put("a", 1, now)
+	s.put("b", 2, now)
+	s.get("a", now) // touch "a" so "b" becomes the least-recently-used
+	s.put("c", 3, now)
+
+	_, aOK := s.get("a", now)
+	_, bOK := s.get("b", now)
+	_, cOK := s.get("c", now)
+
+	assert.True(t, aOK)
+	assert.False(t, bOK, "b should have been evicted as the least-recently-used series")
+	assert.True(t, cOK)
+}
+
+func TestSeriesStoreExpiresEntriesPastTTL(t *testing.T) {
+	s := newSeriesStore(0, time.Minute)
+	now := time.Now()
+
+	s.put("a", 1, now)
+
+	_, stillFresh := s.get("a", now.Add(30*time.Second))
+	assert.True(t, stillFresh)
+
+	_, stale := s.get("a", now.Add(2*time.Minute))
+	assert.False(t, stale, "entries older than the configured TTL should be treated as absent")
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"bytes"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	otlpcollectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+)
+
+// jsonMarshaler is shared by all the OTLP/JSON encoders below. It follows the
+// canonical proto3 JSON mapping: camelCase field names, base64 for bytes,
+// string-encoded int64/uint64, and enums rendered as their string names. This
+// is the wire format required by the OTLP/HTTP receiver/exporter spec.
+var jsonMarshaler = &jsonpb.Marshaler{}
+
+var jsonUnmarshaler = &jsonpb.Unmarshaler{AllowUnknownFields: true}
+
+// otlpJSONMetricsEncoder encodes an ExportMetricsServiceRequest using the
+// OTLP/JSON wire format.
+type otlpJSONMetricsEncoder struct{}
+
+// NewOTLPJSONMetricsEncoder returns a MetricsEncoder that serializes the OTLP
+// ExportMetricsServiceRequest using the canonical proto3 JSON mapping, as
+// required by the OTLP/HTTP spec.
+func NewOTLPJSONMetricsEncoder() MetricsEncoder {
+	return &otlpJSONMetricsEncoder{}
+}
+
+func (e *otlpJSONMetricsEncoder) EncodeMetrics(model interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+	req := model.(*otlpcollectormetrics.ExportMetricsServiceRequest)
+	if err := jsonMarshaler.Marshal(&buf, req); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// otlpJSONMetricsDecoder decodes an ExportMetricsServiceRequest from the
+// OTLP/JSON wire format.
+type otlpJSONMetricsDecoder struct{}
+
+// NewOTLPJSONMetricsDecoder returns a MetricsDecoder that deserializes an OTLP
+// ExportMetricsServiceRequest encoded using the canonical proto3 JSON mapping.
+func NewOTLPJSONMetricsDecoder() MetricsDecoder {
+	return &otlpJSONMetricsDecoder{}
+}
+
+func (d *otlpJSONMetricsDecoder) DecodeMetrics(buf []byte) (interface{}, error) {
+	req := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(buf), req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// otlpJSONTracesEncoder encodes an ExportTraceServiceRequest using the
+// OTLP/JSON wire format.
+type otlpJSONTracesEncoder struct{}
+
+// NewOTLPJSONTracesEncoder returns a TracesEncoder that serializes the OTLP
+// ExportTraceServiceRequest using the canonical proto3 JSON mapping.
+func NewOTLPJSONTracesEncoder() TracesEncoder {
+	return &otlpJSONTracesEncoder{}
+}
+
+func (e *otlpJSONTracesEncoder) EncodeTraces(model interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+	req := model.(*otlpcollectortrace.ExportTraceServiceRequest)
+	if err := jsonMarshaler.Marshal(&buf, req); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// otlpJSONTracesDecoder decodes an ExportTraceServiceRequest from the
+// OTLP/JSON wire format.
+type otlpJSONTracesDecoder struct{}
+
+// NewOTLPJSONTracesDecoder returns a TracesDecoder that deserializes an OTLP
+// ExportTraceServiceRequest encoded using the canonical proto3 JSON mapping.
+func NewOTLPJSONTracesDecoder() TracesDecoder {
+	return &otlpJSONTracesDecoder{}
+}
+
+func (d *otlpJSONTracesDecoder) DecodeTraces(buf []byte) (interface{}, error) {
+	req := &otlpcollectortrace.ExportTraceServiceRequest{}
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(buf), req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// otlpJSONLogsEncoder encodes an ExportLogsServiceRequest using the OTLP/JSON
+// wire format.
+type otlpJSONLogsEncoder struct{}
+
+// NewOTLPJSONLogsEncoder returns a LogsEncoder that serializes the OTLP
+// ExportLogsServiceRequest using the canonical proto3 JSON mapping.
+func NewOTLPJSONLogsEncoder() LogsEncoder {
+	return &otlpJSONLogsEncoder{}
+}
+
+func (e *otlpJSONLogsEncoder) EncodeLogs(model interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+	req := model.(*otlpcollectorlog.ExportLogsServiceRequest)
+	if err := jsonMarshaler.Marshal(&buf, req); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// otlpJSONLogsDecoder decodes an ExportLogsServiceRequest from the OTLP/JSON
+// wire format.
+type otlpJSONLogsDecoder struct{}
+
+// NewOTLPJSONLogsDecoder returns a LogsDecoder that deserializes an OTLP
+// ExportLogsServiceRequest encoded using the canonical proto3 JSON mapping.
+func NewOTLPJSONLogsDecoder() LogsDecoder {
+	return &otlpJSONLogsDecoder{}
+}
+
+func (d *otlpJSONLogsDecoder) DecodeLogs(buf []byte) (interface{}, error) {
+	req := &otlpcollectorlog.ExportLogsServiceRequest{}
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(buf), req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
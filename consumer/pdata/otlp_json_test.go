@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otlpcollectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
+)
+
+// maxUint64 exercises the canonical proto3 JSON mapping's string-encoding of
+// 64-bit integers: the wire format OTLP/HTTP requires (and what jsonpb
+// produces) is a JSON string, to avoid precision loss in JS/other clients
+// whose numbers are float64. The round-trip tests below only check that the
+// value survives encoding and decoding in Go; a plain `encoding/json` number
+// encoding would round-trip it too, so they do not assert the wire form.
+const maxUint64 = uint64(18446744073709551615)
+
+func exampleMetricsRequest() *otlpcollectormetrics.ExportMetricsServiceRequest {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+
+	eh := ilm.Metrics().AppendEmpty()
+	eh.SetName("example.exponential_histogram")
+	eh.SetDataType(MetricDataTypeExponentialHistogram)
+	dp := eh.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(2)
+	dp.SetZeroCount(1)
+	dp.SetCount(maxUint64)
+
+	sum := ilm.Metrics().AppendEmpty()
+	sum.SetName("example.sum")
+	sum.SetDataType(MetricDataTypeIntSum)
+	sdp := sum.IntSum().DataPoints().AppendEmpty()
+	sdp.SetValue(int64(maxUint64 / 2))
+
+	return md.orig
+}
+
+// exampleTraceID and exampleSpanID are 16- and 8-byte IDs, the lengths OTLP
+// actually uses; the canonical proto3 JSON mapping encodes []byte fields as
+// base64, which a naive encoder could get wrong (e.g. hex, or plain bytes).
+var (
+	exampleTraceID = []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	exampleSpanID  = []byte{0xa1, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7, 0xa8}
+)
+
+func exampleTracesRequest() *otlpcollectortrace.ExportTraceServiceRequest {
+	return &otlpcollectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{
+			{
+				InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+					{
+						Spans: []*otlptrace.Span{
+							{
+								TraceId:           exampleTraceID,
+								SpanId:            exampleSpanID,
+								Name:              "example-span",
+								StartTimeUnixNano: maxUint64,
+								EndTimeUnixNano:   maxUint64,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func exampleLogsRequest() *otlpcollectorlog.ExportLogsServiceRequest {
+	return &otlpcollectorlog.ExportLogsServiceRequest{
+		ResourceLogs: []*otlplogs.ResourceLogs{
+			{
+				InstrumentationLibraryLogs: []*otlplogs.InstrumentationLibraryLogs{
+					{
+						Logs: []*otlplogs.LogRecord{
+							{
+								TraceId:      exampleTraceID,
+								SpanId:       exampleSpanID,
+								Name:         "example-log",
+								TimeUnixNano: maxUint64,
+								SeverityText: "ERROR",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestOTLPJSONMetricsRoundTrip(t *testing.T) {
+	want := exampleMetricsRequest()
+
+	b, err := NewOTLPJSONMetricsEncoder().EncodeMetrics(want)
+	require.NoError(t, err)
+
+	got, err := NewOTLPJSONMetricsDecoder().DecodeMetrics(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got.(*otlpcollectormetrics.ExportMetricsServiceRequest))
+}
+
+func TestOTLPJSONTracesRoundTrip(t *testing.T) {
+	want := exampleTracesRequest()
+
+	b, err := NewOTLPJSONTracesEncoder().EncodeTraces(want)
+	require.NoError(t, err)
+
+	got, err := NewOTLPJSONTracesDecoder().DecodeTraces(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got.(*otlpcollectortrace.ExportTraceServiceRequest))
+}
+
+func TestOTLPJSONLogsRoundTrip(t *testing.T) {
+	want := exampleLogsRequest()
+
+	b, err := NewOTLPJSONLogsEncoder().EncodeLogs(want)
+	require.NoError(t, err)
+
+	got, err := NewOTLPJSONLogsDecoder().DecodeLogs(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got.(*otlpcollectorlog.ExportLogsServiceRequest))
+}
+
+func BenchmarkOTLPJSONVsProtobufMetrics(b *testing.B) {
+	req := exampleMetricsRequest()
+	encoder := NewOTLPJSONMetricsEncoder()
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := encoder.EncodeMetrics(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("protobuf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := req.Marshal(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}